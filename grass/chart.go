@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// githubGraphQLURL is overridden in tests to point at an httptest.Server.
+var githubGraphQLURL = "https://api.github.com/graphql"
+
+const (
+	chartCellSize = 10
+	chartCellGap  = 3
+	chartRows     = 7
+)
+
+// contributionDay is a single day's contribution count, as returned by
+// GitHub's contributionCalendar GraphQL field.
+type contributionDay struct {
+	ContributionCount int    `json:"contributionCount"`
+	Date              string `json:"date"`
+}
+
+type contributionWeek struct {
+	ContributionDays []contributionDay `json:"contributionDays"`
+}
+
+// themePalette is the background plus 5-bucket intensity scale used to
+// render a contribution grid, mirroring GitHub's own scheme.
+type themePalette struct {
+	Background color.RGBA
+	Levels     [5]color.RGBA
+}
+
+var chartThemes = map[string]themePalette{
+	"light": {
+		Background: color.RGBA{0xff, 0xff, 0xff, 0xff},
+		Levels: [5]color.RGBA{
+			{0xeb, 0xed, 0xf0, 0xff},
+			{0x9b, 0xe9, 0xa8, 0xff},
+			{0x40, 0xc4, 0x63, 0xff},
+			{0x30, 0xa1, 0x4e, 0xff},
+			{0x21, 0x6e, 0x39, 0xff},
+		},
+	},
+	"dark": {
+		Background: color.RGBA{0x0d, 0x11, 0x17, 0xff},
+		Levels: [5]color.RGBA{
+			{0x16, 0x1b, 0x22, 0xff},
+			{0x0e, 0x44, 0x29, 0xff},
+			{0x00, 0x6d, 0x32, 0xff},
+			{0x26, 0xa6, 0x41, 0xff},
+			{0x39, 0xd3, 0x53, 0xff},
+		},
+	},
+	"halloween": {
+		Background: color.RGBA{0x0d, 0x11, 0x17, 0xff},
+		Levels: [5]color.RGBA{
+			{0xeb, 0xed, 0xf0, 0xff},
+			{0xff, 0xee, 0x4a, 0xff},
+			{0xff, 0xc5, 0x01, 0xff},
+			{0xfd, 0x8c, 0x00, 0xff},
+			{0x03, 0x00, 0x1c, 0xff},
+		},
+	},
+}
+
+const defaultChartTheme = "light"
+
+// parseTheme pulls a `--theme=light|dark|halloween` token out of a
+// slash-command's text, returning the remaining text plus the resolved
+// theme name (falling back to defaultChartTheme for anything unknown).
+func parseTheme(text string) (remaining string, theme string) {
+	theme = defaultChartTheme
+	var kept []string
+	for _, field := range strings.Fields(text) {
+		if strings.HasPrefix(field, "--theme=") {
+			if requested := strings.TrimPrefix(field, "--theme="); isChartTheme(requested) {
+				theme = requested
+			}
+			continue
+		}
+		kept = append(kept, field)
+	}
+	return strings.Join(kept, " "), theme
+}
+
+func isChartTheme(name string) bool {
+	_, ok := chartThemes[name]
+	return ok
+}
+
+type contributionGraphQLResponse struct {
+	Data struct {
+		User struct {
+			ContributionsCollection struct {
+				ContributionCalendar struct {
+					Weeks []contributionWeek `json:"weeks"`
+				} `json:"contributionCalendar"`
+			} `json:"contributionsCollection"`
+		} `json:"user"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+const contributionCalendarQuery = `
+query($login: String!) {
+  user(login: $login) {
+    contributionsCollection {
+      contributionCalendar {
+        weeks {
+          contributionDays {
+            contributionCount
+            date
+          }
+        }
+      }
+    }
+  }
+}`
+
+// fetchContributionWeeks queries GitHub's GraphQL API for username's
+// contribution calendar, using GITHUB_TOKEN for auth (GraphQL requires
+// an authenticated request).
+func fetchContributionWeeks(ctx context.Context, username string) ([]contributionWeek, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITHUB_TOKEN이 설정되어 있지 않습니다")
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     contributionCalendarQuery,
+		"variables": map[string]string{"login": username},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, githubGraphQLURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out contributionGraphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	if len(out.Errors) > 0 {
+		return nil, fmt.Errorf("GitHub GraphQL 오류: %s", out.Errors[0].Message)
+	}
+	return out.Data.User.ContributionsCollection.ContributionCalendar.Weeks, nil
+}
+
+// contributionBucket maps a day's contribution count onto GitHub's
+// classic 5-step intensity scale.
+func contributionBucket(count int) int {
+	switch {
+	case count == 0:
+		return 0
+	case count <= 3:
+		return 1
+	case count <= 6:
+		return 2
+	case count <= 9:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// renderContributionPNG draws weeks as a GitHub-style 7×len(weeks) grid
+// and returns the PNG-encoded result, entirely in memory.
+func renderContributionPNG(weeks []contributionWeek, theme string) (*bytes.Buffer, error) {
+	palette, ok := chartThemes[theme]
+	if !ok {
+		palette = chartThemes[defaultChartTheme]
+	}
+
+	width := chartCellGap + len(weeks)*(chartCellSize+chartCellGap)
+	height := chartCellGap + chartRows*(chartCellSize+chartCellGap)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{palette.Background}, image.Point{}, draw.Src)
+
+	for weekIdx, week := range weeks {
+		for dayIdx, day := range week.ContributionDays {
+			if dayIdx >= chartRows {
+				break
+			}
+			x := chartCellGap + weekIdx*(chartCellSize+chartCellGap)
+			y := chartCellGap + dayIdx*(chartCellSize+chartCellGap)
+			cell := image.Rect(x, y, x+chartCellSize, y+chartCellSize)
+			level := contributionBucket(day.ContributionCount)
+			draw.Draw(img, cell, &image.Uniform{palette.Levels[level]}, image.Point{}, draw.Src)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTheme(t *testing.T) {
+	cases := []struct {
+		text          string
+		wantRemaining string
+		wantTheme     string
+	}{
+		{"octocat --theme=dark", "octocat", "dark"},
+		{"octocat --theme=halloween", "octocat", "halloween"},
+		{"octocat --theme=bogus", "octocat", defaultChartTheme},
+		{"octocat", "octocat", defaultChartTheme},
+	}
+	for _, tc := range cases {
+		remaining, theme := parseTheme(tc.text)
+		if remaining != tc.wantRemaining || theme != tc.wantTheme {
+			t.Errorf("parseTheme(%q) = (%q, %q), want (%q, %q)", tc.text, remaining, theme, tc.wantRemaining, tc.wantTheme)
+		}
+	}
+}
+
+func TestContributionBucket(t *testing.T) {
+	cases := []struct {
+		count int
+		want  int
+	}{
+		{0, 0}, {1, 1}, {3, 1}, {4, 2}, {6, 2}, {7, 3}, {9, 3}, {10, 4}, {50, 4},
+	}
+	for _, tc := range cases {
+		if got := contributionBucket(tc.count); got != tc.want {
+			t.Errorf("contributionBucket(%d) = %d, want %d", tc.count, got, tc.want)
+		}
+	}
+}
+
+func TestRenderContributionPNG(t *testing.T) {
+	weeks := []contributionWeek{
+		{ContributionDays: []contributionDay{{ContributionCount: 0}, {ContributionCount: 5}, {ContributionCount: 12}}},
+		{ContributionDays: []contributionDay{{ContributionCount: 1}}},
+	}
+	buf, err := renderContributionPNG(weeks, "dark")
+	if err != nil {
+		t.Fatalf("renderContributionPNG returned error: %v", err)
+	}
+	img, err := png.Decode(buf)
+	if err != nil {
+		t.Fatalf("expected valid PNG output: %v", err)
+	}
+	bounds := img.Bounds()
+	wantWidth := chartCellGap + len(weeks)*(chartCellSize+chartCellGap)
+	wantHeight := chartCellGap + chartRows*(chartCellSize+chartCellGap)
+	if bounds.Dx() != wantWidth || bounds.Dy() != wantHeight {
+		t.Errorf("got image size %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), wantWidth, wantHeight)
+	}
+}
+
+func TestFetchContributionWeeks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"user": map[string]interface{}{
+					"contributionsCollection": map[string]interface{}{
+						"contributionCalendar": map[string]interface{}{
+							"weeks": []map[string]interface{}{
+								{"contributionDays": []map[string]interface{}{
+									{"contributionCount": 3, "date": "2026-07-20"},
+								}},
+							},
+						},
+					},
+				},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	t.Setenv("GITHUB_TOKEN", "fake-pat")
+	origURL := githubGraphQLURL
+	githubGraphQLURL = ts.URL
+	defer func() { githubGraphQLURL = origURL }()
+
+	weeks, err := fetchContributionWeeks(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("fetchContributionWeeks returned error: %v", err)
+	}
+	if len(weeks) != 1 || weeks[0].ContributionDays[0].ContributionCount != 3 {
+		t.Errorf("unexpected weeks: %+v", weeks)
+	}
+}
+
+func TestFetchContributionWeeks_MissingToken(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	if _, err := fetchContributionWeeks(context.Background(), "octocat"); err == nil {
+		t.Fatal("expected an error when GITHUB_TOKEN is unset")
+	}
+}
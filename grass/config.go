@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joonlog/github-slack-customapp/internal/server"
+)
+
+// TemplateConfig carries the persona a single slash command should reply
+// as: the Slack username/icon shown on the message, distinct from the
+// app's service-wide default identity.
+type TemplateConfig struct {
+	Username  string `json:"username,omitempty"`
+	IconEmoji string `json:"icon_emoji,omitempty"`
+	IconURL   string `json:"icon_url,omitempty"`
+}
+
+// Config holds the service-wide default persona plus any per-command
+// overrides, keyed by command name (e.g. "status", "grass").
+type Config struct {
+	DefaultUsername  string                    `json:"default_username,omitempty"`
+	DefaultIconEmoji string                    `json:"default_icon_emoji,omitempty"`
+	DefaultIconURL   string                    `json:"default_icon_url,omitempty"`
+	Templates        map[string]TemplateConfig `json:"templates,omitempty"`
+}
+
+// loadConfig reads the persona config from the JSON file pointed to by
+// SLACK_TEMPLATES_CONFIG, if set, falling back to service-wide defaults
+// from SLACK_DEFAULT_USERNAME / SLACK_DEFAULT_ICON_EMOJI / SLACK_DEFAULT_ICON_URL.
+func loadConfig() Config {
+	cfg := Config{
+		DefaultUsername:  os.Getenv("SLACK_DEFAULT_USERNAME"),
+		DefaultIconEmoji: os.Getenv("SLACK_DEFAULT_ICON_EMOJI"),
+		DefaultIconURL:   os.Getenv("SLACK_DEFAULT_ICON_URL"),
+	}
+
+	path := os.Getenv("SLACK_TEMPLATES_CONFIG")
+	if path == "" {
+		return cfg
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("⚠️ SLACK_TEMPLATES_CONFIG(%s)을 읽지 못했습니다: %v", path, err)
+		return cfg
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		log.Printf("⚠️ SLACK_TEMPLATES_CONFIG(%s) 파싱 실패: %v", path, err)
+	}
+	return cfg
+}
+
+// personaFor resolves the effective username/icon for command, letting a
+// template-specific value win over the service-wide default, and an
+// explicit per-invocation override win over both.
+func (c Config) personaFor(command string, override TemplateConfig) TemplateConfig {
+	persona := TemplateConfig{
+		Username:  c.DefaultUsername,
+		IconEmoji: c.DefaultIconEmoji,
+		IconURL:   c.DefaultIconURL,
+	}
+	if tmpl, ok := c.Templates[command]; ok {
+		if tmpl.Username != "" {
+			persona.Username = tmpl.Username
+		}
+		if tmpl.IconEmoji != "" {
+			persona.IconEmoji = tmpl.IconEmoji
+		}
+		if tmpl.IconURL != "" {
+			persona.IconURL = tmpl.IconURL
+		}
+	}
+	if override.Username != "" {
+		persona.Username = override.Username
+	}
+	if override.IconEmoji != "" {
+		persona.IconEmoji = override.IconEmoji
+	}
+	if override.IconURL != "" {
+		persona.IconURL = override.IconURL
+	}
+	return persona
+}
+
+// applyTo copies the persona's username/icon fields onto resp.
+func (p TemplateConfig) applyTo(resp *server.SlackResponse) {
+	resp.Username = p.Username
+	resp.IconEmoji = p.IconEmoji
+	resp.IconURL = p.IconURL
+}
+
+// parseOverride pulls `--as=Name` and `--icon=:emoji:` (or a full icon
+// URL) tokens out of a slash-command's text, returning the remaining
+// text plus the per-invocation override they described.
+func parseOverride(text string) (remaining string, override TemplateConfig) {
+	var kept []string
+	for _, field := range strings.Fields(text) {
+		switch {
+		case strings.HasPrefix(field, "--as="):
+			override.Username = strings.TrimPrefix(field, "--as=")
+		case strings.HasPrefix(field, "--icon="):
+			icon := strings.TrimPrefix(field, "--icon=")
+			if strings.HasPrefix(icon, "http://") || strings.HasPrefix(icon, "https://") {
+				override.IconURL = icon
+			} else {
+				override.IconEmoji = icon
+			}
+		default:
+			kept = append(kept, field)
+		}
+	}
+	return strings.Join(kept, " "), override
+}
@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestParseOverride(t *testing.T) {
+	remaining, override := parseOverride("octocat --as=StatBot --icon=:bar_chart:")
+	if remaining != "octocat" {
+		t.Errorf("expected remaining text 'octocat', got %q", remaining)
+	}
+	if override.Username != "StatBot" {
+		t.Errorf("expected username StatBot, got %q", override.Username)
+	}
+	if override.IconEmoji != ":bar_chart:" {
+		t.Errorf("expected icon_emoji :bar_chart:, got %q", override.IconEmoji)
+	}
+}
+
+func TestParseOverride_IconURL(t *testing.T) {
+	_, override := parseOverride("octocat --icon=https://example.com/icon.png")
+	if override.IconURL != "https://example.com/icon.png" {
+		t.Errorf("expected icon_url to be set, got %q", override.IconURL)
+	}
+	if override.IconEmoji != "" {
+		t.Errorf("expected icon_emoji to stay empty, got %q", override.IconEmoji)
+	}
+}
+
+func TestConfig_PersonaFor(t *testing.T) {
+	cfg := Config{
+		DefaultUsername:  "GitHub Bot",
+		DefaultIconEmoji: ":octocat:",
+		Templates: map[string]TemplateConfig{
+			"grass": {Username: "GrassBot"},
+		},
+	}
+
+	persona := cfg.personaFor("grass", TemplateConfig{})
+	if persona.Username != "GrassBot" {
+		t.Errorf("expected template username to win over default, got %q", persona.Username)
+	}
+	if persona.IconEmoji != ":octocat:" {
+		t.Errorf("expected default icon_emoji to carry over, got %q", persona.IconEmoji)
+	}
+
+	override := cfg.personaFor("grass", TemplateConfig{Username: "OneOff"})
+	if override.Username != "OneOff" {
+		t.Errorf("expected per-invocation override to win over template, got %q", override.Username)
+	}
+
+	noTemplate := cfg.personaFor("status", TemplateConfig{})
+	if noTemplate.Username != "GitHub Bot" {
+		t.Errorf("expected default username for command with no template, got %q", noTemplate.Username)
+	}
+}
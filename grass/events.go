@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// slackEventEnvelope is the outer payload Slack's Events API posts to a
+// subscribed request URL.
+type slackEventEnvelope struct {
+	Type      string          `json:"type"`
+	Challenge string          `json:"challenge,omitempty"`
+	TeamID    string          `json:"team_id,omitempty"`
+	Event     json.RawMessage `json:"event,omitempty"`
+}
+
+// slackInnerEvent covers the event shapes this service dispatches on;
+// unused fields for a given event.type are simply left zero.
+type slackInnerEvent struct {
+	Type    string `json:"type"`
+	User    string `json:"user"`
+	Channel string `json:"channel"`
+	Text    string `json:"text"`
+}
+
+// eventHandlers maps an inner event's `type` to the function that
+// handles it. Register new event types here.
+var eventHandlers = map[string]func(context.Context, slackInnerEvent) error{
+	"app_mention": handleAppMentionEvent,
+}
+
+// handleSlackEvents implements Slack's Events API: it answers the
+// one-time url_verification handshake and dispatches event_callback
+// payloads to the registered handler for their event type. By the time
+// it runs, the SlackAuth middleware has already verified the request's
+// signature and restored its body for re-reading here.
+func handleSlackEvents(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var envelope slackEventEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	switch envelope.Type {
+	case "url_verification":
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{"challenge": envelope.Challenge})
+	case "event_callback":
+		w.WriteHeader(http.StatusOK)
+		var inner slackInnerEvent
+		if err := json.Unmarshal(envelope.Event, &inner); err != nil {
+			slog.Error("이벤트 파싱 실패", "error", err)
+			return
+		}
+		handler, ok := eventHandlers[inner.Type]
+		if !ok {
+			return
+		}
+		if err := handler(r.Context(), inner); err != nil {
+			slog.Error("이벤트 처리 실패", "event_type", inner.Type, "error", err)
+		}
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// handleAppMentionEvent runs the same GitHub-user lookup as /status and
+// posts the result back into the channel the mention came from.
+func handleAppMentionEvent(ctx context.Context, event slackInnerEvent) error {
+	username := firstMentionArgument(event.Text)
+	if username == "" {
+		return postMessage(event.Channel, "❗️ 사용자명을 입력해주세요.\n예: `@bot octocat`")
+	}
+	user, err := gh.GetUser(ctx, username)
+	if err != nil {
+		return postMessage(event.Channel, fmt.Sprintf("❌ 사용자 `%s` 정보를 불러올 수 없습니다.\n> %v", username, err))
+	}
+	return postMessage(event.Channel, formatGitHubStatusText(user))
+}
+
+// firstMentionArgument returns the first word of text that isn't a
+// `<@U...>` mention token, e.g. "<@U123> octocat" -> "octocat".
+func firstMentionArgument(text string) string {
+	for _, field := range strings.Fields(text) {
+		if strings.HasPrefix(field, "<@") {
+			continue
+		}
+		return field
+	}
+	return ""
+}
+
+// postMessage posts text to channelID via chat.postMessage.
+func postMessage(channelID, text string) error {
+	botToken := os.Getenv("SLACK_BOT_TOKEN")
+	if botToken == "" {
+		return fmt.Errorf("SLACK_BOT_TOKEN이 설정되어 있지 않습니다")
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"channel": channelID,
+		"text":    text,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, slackAPIBaseURL+"/chat.postMessage", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+botToken)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return err
+	}
+	if !out.OK {
+		return fmt.Errorf("chat.postMessage: %s", out.Error)
+	}
+	return nil
+}
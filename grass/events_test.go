@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ghclient "github.com/joonlog/github-slack-customapp/internal/github"
+)
+
+func TestHandleSlackEvents_URLVerification(t *testing.T) {
+	body := `{"type":"url_verification","challenge":"abc123"}`
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handleSlackEvents(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var out map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&out); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if out["challenge"] != "abc123" {
+		t.Errorf("expected challenge echoed back, got %q", out["challenge"])
+	}
+}
+
+func TestHandleSlackEvents_UnknownEventType(t *testing.T) {
+	body := `{"type":"event_callback","event":{"type":"reaction_added"}}`
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handleSlackEvents(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for an unregistered event type, got %d", rec.Code)
+	}
+}
+
+func TestHandleSlackEvents_AppMention(t *testing.T) {
+	withFakeGitHubClient(t, &fakeGitHubClient{user: &ghclient.User{
+		Login: "octocat",
+		Name:  "The Octocat",
+	}})
+
+	var gotChannel, gotText string
+	withFakeSlackAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postMessage" {
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var payload struct {
+			Channel string `json:"channel"`
+			Text    string `json:"text"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode chat.postMessage payload: %v", err)
+		}
+		gotChannel, gotText = payload.Channel, payload.Text
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	})
+
+	body := `{"type":"event_callback","event":{"type":"app_mention","channel":"C12345","text":"<@UBOT123> octocat"}}`
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handleSlackEvents(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if gotChannel != "C12345" {
+		t.Errorf("expected chat.postMessage channel C12345, got %q", gotChannel)
+	}
+	if !strings.Contains(gotText, "The Octocat") {
+		t.Errorf("expected posted text to contain the GitHub profile summary, got %q", gotText)
+	}
+}
+
+func TestFirstMentionArgument(t *testing.T) {
+	got := firstMentionArgument("<@U123ABC> octocat")
+	if got != "octocat" {
+		t.Errorf("expected 'octocat', got %q", got)
+	}
+}
@@ -1,78 +1,113 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
-	"os/exec"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	ghclient "github.com/joonlog/github-slack-customapp/internal/github"
+	"github.com/joonlog/github-slack-customapp/internal/server"
 )
 
-type SlackResponse struct {
-	ResponseType string        `json:"response_type"`
-	Blocks       []interface{} `json:"blocks,omitempty"`
-	Text         string        `json:"text,omitempty"`
+// githubClient is the subset of *ghclient.Client the Slack handlers and
+// /healthz depend on; it exists so tests can swap gh for a fake upstream
+// without standing in an httptest.Server for the real GitHub API.
+type githubClient interface {
+	GetUser(ctx context.Context, username string) (*ghclient.User, error)
+	RateLimit() (remaining int, reset time.Time)
 }
 
-type GitHubUser struct {
-	Login       string `json:"login"`
-	Name        string `json:"name"`
-	PublicRepos int    `json:"public_repos"`
-	Followers   int    `json:"followers"`
-	Bio         string `json:"bio"`
-	HTMLURL     string `json:"html_url"`
-}
+// slackAPIBaseURL is overridden in tests to point at an httptest.Server
+// standing in for slack.com.
+var slackAPIBaseURL = "https://slack.com/api"
 
-func handleSlackStatus(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
-		return
-	}
-	if err := r.ParseForm(); err != nil {
-		http.Error(w, "Failed to parse form", http.StatusBadRequest)
-		return
-	}
-	slackToken := r.FormValue("token")
-	expectedToken := os.Getenv("SLACK_VERIFICATION_TOKEN")
-	if expectedToken == "" {
-		log.Println("⚠️ SLACK_VERIFICATION_TOKEN이 설정되어 있지 않습니다.")
+// gh is the shared GitHub API client used by every handler; it caches
+// responses and tracks remaining rate-limit quota for /healthz.
+var gh githubClient = ghclient.NewClient(os.Getenv("GITHUB_TOKEN"), newGitHubCache())
+
+// newGitHubCache selects gh's caching backend: Redis when REDIS_ADDR is
+// set, so cached ETags are shared across multiple instances of the
+// service, falling back to an in-process LRU otherwise.
+func newGitHubCache() ghclient.Cache {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		return ghclient.NewMemoryCache(256)
 	}
-	if slackToken != expectedToken {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: os.Getenv("REDIS_PASSWORD"),
+	})
+	return ghclient.NewRedisCache(rdb, cacheTTL())
+}
+
+// cacheTTL returns how long cached GitHub responses remain valid in
+// Redis, configurable via REDIS_CACHE_TTL_SECONDS (default 1h).
+func cacheTTL() time.Duration {
+	if raw := os.Getenv("REDIS_CACHE_TTL_SECONDS"); raw != "" {
+		if seconds, err := strconv.Atoi(raw); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
 	}
-	rawText := strings.TrimSpace(r.FormValue("text"))
+	return time.Hour
+}
+
+// appConfig holds the service-wide and per-command persona settings,
+// loaded once at startup.
+var appConfig = loadConfig()
+
+// handleSlackStatus is the business logic for /status: by the time it
+// runs, the Slack auth/signature middleware has already verified the
+// request and parsed its form.
+func handleSlackStatus(r *http.Request) (server.SlackResponse, error) {
+	rawText, override := parseOverride(strings.TrimSpace(r.FormValue("text")))
+	persona := appConfig.personaFor("status", override)
+	rawText = strings.TrimSpace(rawText)
 	if rawText == "" {
-		resp := SlackResponse{
+		resp := server.SlackResponse{
 			ResponseType: "ephemeral",
 			Text:         "❗️ 사용자명을 입력해주세요.\n예: `/status octocat`",
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(resp)
-		return
+		persona.applyTo(&resp)
+		return resp, nil
 	}
 	username := strings.Fields(rawText)[0]
-	user, err := fetchGitHubUser(username)
+	user, err := gh.GetUser(r.Context(), username)
 	if err != nil {
-		resp := SlackResponse{
+		resp := server.SlackResponse{
 			ResponseType: "ephemeral",
 			Text:         fmt.Sprintf("❌ 사용자 `%s` 정보를 불러올 수 없습니다.\n> %v", username, err),
 		}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(resp)
-		return
+		persona.applyTo(&resp)
+		return resp, nil
+	}
+	resp := server.SlackResponse{
+		ResponseType: "in_channel",
+		Text:         formatGitHubStatusText(user),
 	}
+	persona.applyTo(&resp)
+	return resp, nil
+}
+
+// formatGitHubStatusText renders the same GitHub profile summary used by
+// /status so it can also be posted by the Events API's app_mention handler.
+func formatGitHubStatusText(user *ghclient.User) string {
 	displayName := user.Name
 	if displayName == "" {
 		displayName = user.Login
 	}
-	text := fmt.Sprintf(
+	return fmt.Sprintf(
 		":bar_chart: *%s* (`%s`)\n"+
 			"- :card_index_dividers: Public Repos: %d\n"+
 			"- :busts_in_silhouette: Followers: %d\n"+
@@ -85,124 +120,256 @@ func handleSlackStatus(w http.ResponseWriter, r *http.Request) {
 		nullToEmpty(user.Bio),
 		user.HTMLURL,
 	)
-	resp := SlackResponse{
-		ResponseType: "in_channel",
-		Text:         text,
+}
+
+func nullToEmpty(s string) string {
+	if s == "" {
+		return "no bio"
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(resp)
+	return s
 }
 
-func fetchGitHubUser(username string) (*GitHubUser, error) {
-	url := fmt.Sprintf("https://api.github.com/users/%s", username)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+// handleSlackGrass is the business logic for /grass: by the time it
+// runs, the Slack auth/signature middleware has already verified the
+// request and parsed its form.
+func handleSlackGrass(r *http.Request) (server.SlackResponse, error) {
+	rawText, override := parseOverride(strings.TrimSpace(r.FormValue("text")))
+	rawText, theme := parseTheme(rawText)
+	persona := appConfig.personaFor("grass", override)
+	slog.Debug("grass command text parsed", "text", rawText, "theme", theme)
+
+	parts := strings.Fields(rawText)
+	if len(parts) == 0 {
+		resp := server.SlackResponse{
+			ResponseType: "ephemeral",
+			Text:         "❗️ 사용자명을 입력해주세요.\n예: `/grass joonlog`",
+		}
+		persona.applyTo(&resp)
+		return resp, nil
 	}
-	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		req.Header.Set("Authorization", "token "+token)
+
+	username := parts[0]
+
+	weeks, err := fetchContributionWeeks(r.Context(), username)
+	if err != nil {
+		resp := server.SlackResponse{
+			ResponseType: "ephemeral",
+			Text:         fmt.Sprintf("❌ 잔디 데이터를 불러올 수 없습니다.\n> %v", err),
+		}
+		persona.applyTo(&resp)
+		return resp, nil
 	}
-	req.Header.Set("User-Agent", "Go-Slack-GitHub-Bot")
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Do(req)
+
+	png, err := renderContributionPNG(weeks, theme)
 	if err != nil {
-		return nil, err
+		return server.SlackResponse{}, fmt.Errorf("잔디 이미지 렌더링 실패: %w", err)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub 응답 코드 %d: %s", resp.StatusCode, string(body))
+
+	channelID := r.FormValue("channel_id")
+	if err := uploadPNGToSlack(png.Bytes(), channelID, username); err != nil {
+		resp := server.SlackResponse{
+			ResponseType: "ephemeral",
+			Text:         fmt.Sprintf("❌ Slack 업로드 실패\n> %v", err),
+		}
+		persona.applyTo(&resp)
+		return resp, nil
 	}
-	var user GitHubUser
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		return nil, err
+
+	resp := server.SlackResponse{
+		ResponseType: "ephemeral",
+		Text:         "✅ 잔디 이미지를 채널에 업로드했습니다.",
 	}
-	return &user, nil
+	persona.applyTo(&resp)
+	return resp, nil
 }
 
-func nullToEmpty(s string) string {
-	if s == "" {
-		return "no bio"
+// uploadURLResponse is the payload returned by files.getUploadURLExternal.
+type uploadURLResponse struct {
+	OK        bool   `json:"ok"`
+	Error     string `json:"error"`
+	UploadURL string `json:"upload_url"`
+	FileID    string `json:"file_id"`
+}
+
+// completeUploadResponse is the payload returned by files.completeUploadExternal.
+type completeUploadResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// uploadPNGToSlack posts pngData to channelID using Slack's
+// files.getUploadURLExternal / files.completeUploadExternal flow, since
+// the legacy files.upload endpoint is deprecated.
+func uploadPNGToSlack(pngData []byte, channelID, username string) error {
+	botToken := os.Getenv("SLACK_BOT_TOKEN")
+	if botToken == "" {
+		return fmt.Errorf("SLACK_BOT_TOKEN이 설정되어 있지 않습니다")
 	}
-	return s
+
+	filename := fmt.Sprintf("%s-grass.png", username)
+	uploadURL, fileID, err := getUploadURLExternal(botToken, filename, len(pngData))
+	if err != nil {
+		return err
+	}
+
+	if err := putFile(uploadURL, filename, pngData); err != nil {
+		return err
+	}
+
+	comment := fmt.Sprintf("🌱 @%s's GitHub contributions", username)
+	return completeUploadExternal(botToken, fileID, channelID, comment)
 }
 
-func handleSlackGrass(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
-		return
+func getUploadURLExternal(botToken, filename string, length int) (uploadURL, fileID string, err error) {
+	form := url.Values{}
+	form.Set("filename", filename)
+	form.Set("length", strconv.Itoa(length))
+
+	req, err := http.NewRequest(http.MethodPost, slackAPIBaseURL+"/files.getUploadURLExternal", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+botToken)
 
-	if err := r.ParseForm(); err != nil {
-		http.Error(w, "Failed to parse form", http.StatusBadRequest)
-		return
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", err
 	}
+	defer resp.Body.Close()
 
-	rawText := strings.TrimSpace(r.FormValue("text"))
-	log.Printf("🔤 Slack 입력 text: '%s'", rawText)
+	var out uploadURLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", "", err
+	}
+	if !out.OK {
+		return "", "", fmt.Errorf("files.getUploadURLExternal: %s", out.Error)
+	}
+	return out.UploadURL, out.FileID, nil
+}
 
-	parts := strings.Fields(rawText)
-	if len(parts) == 0 {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		json.NewEncoder(w).Encode(SlackResponse{
-			ResponseType: "ephemeral",
-			Text:         "❗️ 사용자명을 입력해주세요.\n예: `/grass joonlog`",
-		})
-		return
+func putFile(uploadURL, filename string, data []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
 	}
 
-	username := parts[0]
-	log.Printf("🌱 /grass 요청 수신됨 - username: %s", username)
+	req, err := http.NewRequest(http.MethodPost, uploadURL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	svgURL := fmt.Sprintf("https://ghchart.rshah.org/%s", username)
-	resp, err := http.Get(svgURL)
-	if err != nil || resp.StatusCode != 200 {
-		log.Printf("SVG 요청 실패: %v", err)
-		http.Error(w, "GitHub chart를 가져오지 못했습니다.", http.StatusBadGateway)
-		return
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("업로드 URL로 파일 전송 실패 (%d): %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+func completeUploadExternal(botToken, fileID, channelID, initialComment string) error {
+	payload := map[string]interface{}{
+		"files": []map[string]string{
+			{"id": fileID},
+		},
+		"channel_id":      channelID,
+		"initial_comment": initialComment,
+	}
+	bodyBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
 
-	// SVG 저장
-	svgData, err := io.ReadAll(resp.Body)
+	req, err := http.NewRequest(http.MethodPost, slackAPIBaseURL+"/files.completeUploadExternal", bytes.NewReader(bodyBytes))
 	if err != nil {
-		http.Error(w, "SVG 읽기 실패", http.StatusInternalServerError)
-		return
+		return err
 	}
-	tmpSvg := fmt.Sprintf("/tmp/%s.svg", username)
-	if err := os.WriteFile(tmpSvg, svgData, 0644); err != nil {
-		http.Error(w, "SVG 저장 실패", http.StatusInternalServerError)
-		return
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+botToken)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
 
-	// PNG로 변환 (rsvg-convert 필요)
-	tmpPng := fmt.Sprintf("/tmp/%s.png", username)
-	cmd := exec.Command("rsvg-convert", "-o", tmpPng, tmpSvg)
-	if err := cmd.Run(); err != nil {
-		http.Error(w, "PNG 변환 실패", http.StatusInternalServerError)
-		return
+	var out completeUploadResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return err
+	}
+	if !out.OK {
+		return fmt.Errorf("files.completeUploadExternal: %s", out.Error)
 	}
+	return nil
+}
 
-	// 슬랙에 PNG 업로드는 아직 안함 — 여기까지는 파일 생성까지만
+// healthzResponse reports the service's current GitHub API quota, as
+// last observed from response headers.
+type healthzResponse struct {
+	Status                   string `json:"status"`
+	GitHubRateLimitRemaining int    `json:"github_rate_limit_remaining"`
+	GitHubRateLimitReset     string `json:"github_rate_limit_reset,omitempty"`
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	remaining, reset := gh.RateLimit()
+	resp := healthzResponse{
+		Status:                   "ok",
+		GitHubRateLimitRemaining: remaining,
+	}
+	if !reset.IsZero() {
+		resp.GitHubRateLimitReset = reset.Format(time.RFC3339)
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(SlackResponse{
-		ResponseType: "ephemeral",
-		Text:         fmt.Sprintf("✅ SVG → PNG 변환 성공: %s", tmpPng),
-	})
+	json.NewEncoder(w).Encode(resp)
+}
+
+// shutdownTimeout returns how long Run should wait for in-flight
+// requests to drain after a SIGTERM/SIGINT, configurable via
+// SHUTDOWN_TIMEOUT_SECONDS (default 10s).
+func shutdownTimeout() time.Duration {
+	if raw := os.Getenv("SHUTDOWN_TIMEOUT_SECONDS"); raw != "" {
+		if seconds, err := strconv.Atoi(raw); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 10 * time.Second
 }
 
 func main() {
-	if os.Getenv("SLACK_VERIFICATION_TOKEN") == "" {
-		log.Println("⚠️ SLACK_VERIFICATION_TOKEN이 설정되어 있지 않습니다. 토큰 검증을 건너뜁니다.")
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, nil)))
+	if os.Getenv("SLACK_VERIFICATION_TOKEN") == "" && os.Getenv("SLACK_SIGNING_SECRET") == "" {
+		slog.Warn("SLACK_SIGNING_SECRET, SLACK_VERIFICATION_TOKEN이 모두 설정되어 있지 않습니다. 토큰 검증을 건너뜁니다.")
 	}
-	mux := http.NewServeMux()
-	mux.HandleFunc("/status", handleSlackStatus)
-	mux.HandleFunc("/grass", handleSlackGrass)
+
+	router := server.NewRouter(server.RequestID, server.AccessLog, server.Recovery)
+	router.Handle("/status", server.Chain(server.Adapt("status", handleSlackStatus), server.SlackAuth))
+	router.Handle("/grass", server.Chain(server.Adapt("grass", handleSlackGrass), server.SlackAuth))
+	router.Handle("/events", server.Chain(http.HandlerFunc(handleSlackEvents), server.SlackAuth))
+	router.HandleFunc("/healthz", handleHealthz)
+	router.Handle("/metrics", server.MetricsHandler())
 
 	port := "8080"
-	fmt.Printf("서버 실행 중... http://localhost:%s/{status,grass}\n", port)
-	log.Fatal(http.ListenAndServe(":"+port, mux))
+	slog.Info("서버 실행 중...", "addr", "http://localhost:"+port)
+	if err := server.Run(":"+port, router, shutdownTimeout()); err != nil {
+		slog.Error("server exited with error", "error", err)
+		os.Exit(1)
+	}
 }
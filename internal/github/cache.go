@@ -0,0 +1,81 @@
+package github
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// CacheEntry is what Cache stores for a given key: the response body
+// plus the ETag it was served with, so later requests can be made
+// conditional.
+type CacheEntry struct {
+	ETag string
+	Body []byte
+}
+
+// Cache is the storage backend for conditional-request caching. The
+// default implementation is an in-memory LRU (NewMemoryCache); a Redis
+// implementation is available via NewRedisCache for sharing a cache
+// across instances.
+type Cache interface {
+	Get(ctx context.Context, key string) (CacheEntry, bool)
+	Set(ctx context.Context, key string, entry CacheEntry)
+}
+
+// memoryCache is a fixed-capacity, in-process LRU cache.
+type memoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewMemoryCache builds an in-memory LRU Cache holding at most capacity
+// entries.
+func NewMemoryCache(capacity int) Cache {
+	return &memoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheItem).entry, true
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*memoryCacheItem).entry = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	c.items[key] = elem
+
+	if c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryCacheItem).key)
+		}
+	}
+}
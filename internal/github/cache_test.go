@@ -0,0 +1,41 @@
+package github
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryCache_GetSet(t *testing.T) {
+	cache := NewMemoryCache(2)
+	ctx := context.Background()
+
+	if _, ok := cache.Get(ctx, "missing"); ok {
+		t.Fatal("expected miss for unset key")
+	}
+
+	cache.Set(ctx, "a", CacheEntry{ETag: "etag-a", Body: []byte("a")})
+	entry, ok := cache.Get(ctx, "a")
+	if !ok || entry.ETag != "etag-a" {
+		t.Fatalf("expected cached entry for 'a', got %+v ok=%v", entry, ok)
+	}
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	ctx := context.Background()
+
+	cache.Set(ctx, "a", CacheEntry{ETag: "a"})
+	cache.Set(ctx, "b", CacheEntry{ETag: "b"})
+	cache.Get(ctx, "a") // touch "a" so "b" becomes the least recently used
+	cache.Set(ctx, "c", CacheEntry{ETag: "c"})
+
+	if _, ok := cache.Get(ctx, "b"); ok {
+		t.Error("expected 'b' to be evicted")
+	}
+	if _, ok := cache.Get(ctx, "a"); !ok {
+		t.Error("expected 'a' to survive eviction")
+	}
+	if _, ok := cache.Get(ctx, "c"); !ok {
+		t.Error("expected 'c' to be present")
+	}
+}
@@ -0,0 +1,200 @@
+// Package github provides a caching, rate-limit-aware client for the
+// GitHub REST API, replacing the ad-hoc http.Get calls the Slack
+// handlers used to make directly.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const defaultUserAgent = "Go-Slack-GitHub-Bot"
+
+// userEndpoint builds the GitHub API URL for a user lookup; overridden
+// in tests to point at an httptest.Server.
+var userEndpoint = func(username string) string {
+	return fmt.Sprintf("https://api.github.com/users/%s", username)
+}
+
+// User is the subset of GitHub's user resource the bot surfaces in Slack.
+type User struct {
+	Login       string `json:"login"`
+	Name        string `json:"name"`
+	PublicRepos int    `json:"public_repos"`
+	Followers   int    `json:"followers"`
+	Bio         string `json:"bio"`
+	HTMLURL     string `json:"html_url"`
+	AvatarURL   string `json:"avatar_url"`
+}
+
+// RateLimitError is returned when GitHub reports the client is out of
+// quota, carrying enough detail to decide when to retry.
+type RateLimitError struct {
+	Remaining  int
+	Reset      time.Time
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("github rate limit exceeded, resets at %s (retry after %s)", e.Reset.Format(time.RFC3339), e.RetryAfter)
+}
+
+// Client is a caching, rate-limit-aware GitHub REST API client. The
+// zero value is not usable; construct one with NewClient.
+type Client struct {
+	httpClient *http.Client
+	token      string
+	cache      Cache
+	group      singleflight.Group
+
+	mu        sync.RWMutex
+	remaining int
+	reset     time.Time
+}
+
+// NewClient builds a Client that authenticates with token (may be empty
+// for unauthenticated, lower-quota requests) and caches responses in
+// cache (pass nil to disable caching).
+func NewClient(token string, cache Cache) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		token:      token,
+		cache:      cache,
+	}
+}
+
+// GetUser fetches a GitHub user's profile, serving a cached copy on a
+// conditional-request 304 and coalescing concurrent lookups of the same
+// username into a single upstream call.
+func (c *Client) GetUser(ctx context.Context, username string) (*User, error) {
+	result, err, _ := c.group.Do(username, func() (interface{}, error) {
+		return c.fetchUser(ctx, username)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*User), nil
+}
+
+func (c *Client) fetchUser(ctx context.Context, username string) (*User, error) {
+	cacheKey := "user:" + username
+
+	var cached CacheEntry
+	var hasCache bool
+	if c.cache != nil {
+		cached, hasCache = c.cache.Get(ctx, cacheKey)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, userEndpoint(username), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+	req.Header.Set("User-Agent", defaultUserAgent)
+	if hasCache && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		requestDuration.WithLabelValues("users", "error").Observe(time.Since(start).Seconds())
+		return nil, err
+	}
+	defer resp.Body.Close()
+	requestDuration.WithLabelValues("users", strconv.Itoa(resp.StatusCode)).Observe(time.Since(start).Seconds())
+
+	c.recordRateLimit(resp.Header)
+
+	if rateLimitErr := c.rateLimitErrorFor(resp); rateLimitErr != nil {
+		return nil, rateLimitErr
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hasCache {
+		var user User
+		if err := json.Unmarshal(cached.Body, &user); err != nil {
+			return nil, err
+		}
+		return &user, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("GitHub 응답 코드 %d: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, err
+	}
+
+	if etag := resp.Header.Get("ETag"); c.cache != nil && etag != "" {
+		c.cache.Set(ctx, cacheKey, CacheEntry{ETag: etag, Body: body})
+	}
+
+	return &user, nil
+}
+
+// rateLimitErrorFor reports whether resp signals the client is out of
+// GitHub API quota (primary limit exhaustion or a secondary rate limit).
+func (c *Client) rateLimitErrorFor(resp *http.Response) error {
+	remaining, reset := c.RateLimit()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{Remaining: remaining, Reset: reset, RetryAfter: retryAfter(resp.Header)}
+	}
+	if resp.StatusCode == http.StatusForbidden && remaining == 0 {
+		return &RateLimitError{Remaining: remaining, Reset: reset, RetryAfter: time.Until(reset)}
+	}
+	return nil
+}
+
+func retryAfter(h http.Header) time.Duration {
+	seconds, err := strconv.Atoi(h.Get("Retry-After"))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// recordRateLimit updates the client's view of its remaining GitHub
+// quota from the response headers, so /healthz can report it.
+func (c *Client) recordRateLimit(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	var reset time.Time
+	if resetUnix, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		reset = time.Unix(resetUnix, 0)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remaining = remaining
+	if !reset.IsZero() {
+		c.reset = reset
+	}
+}
+
+// RateLimit reports the client's most recently observed GitHub API
+// quota.
+func (c *Client) RateLimit() (remaining int, reset time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.remaining, c.reset
+}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_GetUser(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"abc123"`)
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		w.Header().Set("X-RateLimit-Reset", "9999999999")
+		w.Write([]byte(`{"login":"octocat","name":"The Octocat","public_repos":8}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("", NewMemoryCache(16))
+	client.httpClient = ts.Client()
+	origEndpoint := userEndpoint
+	userEndpoint = func(username string) string { return ts.URL + "/users/" + username }
+	defer func() { userEndpoint = origEndpoint }()
+
+	user, err := client.GetUser(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("GetUser returned error: %v", err)
+	}
+	if user.Login != "octocat" || user.PublicRepos != 8 {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	remaining, _ := client.RateLimit()
+	if remaining != 59 {
+		t.Errorf("expected remaining=59, got %d", remaining)
+	}
+}
+
+func TestClient_GetUser_ConditionalRequestServesCache(t *testing.T) {
+	var hits int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if r.Header.Get("If-None-Match") == `"abc123"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"abc123"`)
+		w.Write([]byte(`{"login":"octocat"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("", NewMemoryCache(16))
+	client.httpClient = ts.Client()
+	origEndpoint := userEndpoint
+	userEndpoint = func(username string) string { return ts.URL + "/users/" + username }
+	defer func() { userEndpoint = origEndpoint }()
+
+	if _, err := client.GetUser(context.Background(), "octocat"); err != nil {
+		t.Fatalf("first GetUser returned error: %v", err)
+	}
+	user, err := client.GetUser(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("second GetUser returned error: %v", err)
+	}
+	if user.Login != "octocat" {
+		t.Errorf("expected cached user to round-trip, got %+v", user)
+	}
+	if atomic.LoadInt32(&hits) != 2 {
+		t.Errorf("expected 2 upstream requests (second a 304), got %d", hits)
+	}
+}
+
+func TestClient_GetUser_CoalescesConcurrentCalls(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Write([]byte(`{"login":"octocat"}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("", NewMemoryCache(16))
+	client.httpClient = ts.Client()
+	origEndpoint := userEndpoint
+	userEndpoint = func(username string) string { return ts.URL + "/users/" + username }
+	defer func() { userEndpoint = origEndpoint }()
+
+	const burst = 10
+	var ready, wg sync.WaitGroup
+	ready.Add(burst)
+	wg.Add(burst)
+	for i := 0; i < burst; i++ {
+		go func() {
+			defer wg.Done()
+			ready.Done()
+			user, err := client.GetUser(context.Background(), "octocat")
+			if err != nil {
+				t.Errorf("GetUser returned error: %v", err)
+				return
+			}
+			if user.Login != "octocat" {
+				t.Errorf("unexpected user: %+v", user)
+			}
+		}()
+	}
+
+	// Wait for every goroutine to have at least started before letting the
+	// single in-flight upstream request complete, so they all join the
+	// same singleflight.Group call instead of racing a second one in.
+	ready.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("expected a burst of concurrent GetUser calls to coalesce into 1 upstream request, got %d", got)
+	}
+}
+
+func TestClient_GetUser_RateLimited(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "9999999999")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	client := NewClient("", nil)
+	client.httpClient = ts.Client()
+	origEndpoint := userEndpoint
+	userEndpoint = func(username string) string { return ts.URL + "/users/" + username }
+	defer func() { userEndpoint = origEndpoint }()
+
+	_, err := client.GetUser(context.Background(), "octocat")
+	if err == nil {
+		t.Fatal("expected a RateLimitError")
+	}
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Errorf("expected *RateLimitError, got %T: %v", err, err)
+	}
+}
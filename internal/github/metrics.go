@@ -0,0 +1,15 @@
+package github
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// requestDuration tracks upstream GitHub API latency, by endpoint and
+// response status, so it shows up on the service's /metrics endpoint
+// alongside the Slack command counters.
+var requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "github_api_request_duration_seconds",
+	Help:    "Latency of requests made to the GitHub API, by endpoint and status.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"endpoint", "status"})
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCache is a Cache implementation backed by Redis, for sharing
+// conditional-request state across multiple instances of the service.
+type redisCache struct {
+	rdb *redis.Client
+	ttl time.Duration
+}
+
+// NewRedisCache builds a Cache that stores entries in Redis via rdb,
+// expiring them after ttl.
+func NewRedisCache(rdb *redis.Client, ttl time.Duration) Cache {
+	return &redisCache{rdb: rdb, ttl: ttl}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) (CacheEntry, bool) {
+	raw, err := c.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, entry CacheEntry) {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	c.rdb.Set(ctx, key, raw, c.ttl)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signRequest(t *testing.T, signingSecret string, timestamp int64, body string) string {
+	t.Helper()
+	baseString := "v0:" + strconv.FormatInt(timestamp, 10) + ":" + body
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte(baseString))
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySlackSignature_Valid(t *testing.T) {
+	secret := "shhh"
+	body := `{"type":"event_callback"}`
+	ts := time.Now().Unix()
+	sig := signRequest(t, secret, ts, body)
+
+	if !VerifySlackSignature(secret, strconv.FormatInt(ts, 10), []byte(body), sig) {
+		t.Fatal("expected a validly-signed request to pass verification")
+	}
+}
+
+func TestVerifySlackSignature_WrongSecret(t *testing.T) {
+	body := `{"type":"event_callback"}`
+	ts := time.Now().Unix()
+	sig := signRequest(t, "shhh", ts, body)
+
+	if VerifySlackSignature("different-secret", strconv.FormatInt(ts, 10), []byte(body), sig) {
+		t.Fatal("expected verification to fail with the wrong secret")
+	}
+}
+
+func TestVerifySlackSignature_StaleTimestamp(t *testing.T) {
+	secret := "shhh"
+	body := `{"type":"event_callback"}`
+	ts := time.Now().Add(-10 * time.Minute).Unix()
+	sig := signRequest(t, secret, ts, body)
+
+	if VerifySlackSignature(secret, strconv.FormatInt(ts, 10), []byte(body), sig) {
+		t.Fatal("expected a stale timestamp to fail verification")
+	}
+}
+
+func TestSlackAuth_ValidSignature(t *testing.T) {
+	secret := "shhh"
+	t.Setenv("SLACK_SIGNING_SECRET", secret)
+
+	body := "token=xyz&text=hello"
+	ts := time.Now().Unix()
+	sig := signRequest(t, secret, ts, body)
+
+	called := false
+	handler := SlackAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(ts, 10))
+	req.Header.Set("X-Slack-Signature", sig)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if !called {
+		t.Fatal("expected the wrapped handler to run")
+	}
+}
+
+func TestSlackAuth_InvalidSignature(t *testing.T) {
+	t.Setenv("SLACK_SIGNING_SECRET", "shhh")
+
+	body := "token=xyz"
+	req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	req.Header.Set("X-Slack-Signature", "v0=bogus")
+	rec := httptest.NewRecorder()
+
+	handler := SlackAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("wrapped handler should not run for an invalid signature")
+	}))
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestSlackAuth_LegacyVerificationToken(t *testing.T) {
+	t.Setenv("SLACK_VERIFICATION_TOKEN", "legacy-token")
+
+	body := "token=legacy-token&text=hello"
+	req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	called := false
+	handler := SlackAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	handler.ServeHTTP(rec, req)
+
+	if !called {
+		t.Fatal("expected the legacy verification token to be accepted when no signing secret is set")
+	}
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// CommandHandler is the business logic for a single Slack slash
+// command: given the (already form-parsed and auth-verified) request,
+// it returns the response to send back, or an error for truly
+// unexpected failures. Known, user-facing failures (bad input, a
+// downstream API error) should be reported as an ephemeral
+// SlackResponse, not as an error.
+type CommandHandler func(r *http.Request) (SlackResponse, error)
+
+// Adapt turns a CommandHandler into an http.HandlerFunc that enforces
+// POST, JSON-encodes whatever the handler returns, and falls back to a
+// generic ephemeral error message (logging the real cause) when the
+// handler itself fails unexpectedly.
+func Adapt(command string, h CommandHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp, err := h(r)
+		status := "ok"
+		if err != nil {
+			status = "error"
+			slog.Error("command handler failed", "command", command, "error", err)
+			resp = SlackResponse{
+				ResponseType: "ephemeral",
+				Text:         "❌ 내부 오류가 발생했습니다.",
+			}
+		}
+		ObserveRequest(command, status)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(resp)
+	}
+}
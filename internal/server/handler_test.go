@@ -0,0 +1,63 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdapt_Success(t *testing.T) {
+	h := Adapt("status", func(r *http.Request) (SlackResponse, error) {
+		return SlackResponse{ResponseType: "in_channel", Text: "ok"}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/status", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var out SlackResponse
+	if err := json.NewDecoder(rec.Body).Decode(&out); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if out.Text != "ok" {
+		t.Errorf("expected text 'ok', got %q", out.Text)
+	}
+}
+
+func TestAdapt_HandlerError(t *testing.T) {
+	h := Adapt("status", func(r *http.Request) (SlackResponse, error) {
+		return SlackResponse{}, errors.New("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodPost, "/status", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 even on handler error (Slack expects 200), got %d", rec.Code)
+	}
+	var out SlackResponse
+	if err := json.NewDecoder(rec.Body).Decode(&out); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if out.ResponseType != "ephemeral" {
+		t.Errorf("expected a generic ephemeral error response, got %+v", out)
+	}
+}
+
+func TestAdapt_MethodNotAllowed(t *testing.T) {
+	h := Adapt("status", func(r *http.Request) (SlackResponse, error) {
+		t.Fatal("handler should not run for a non-POST request")
+		return SlackResponse{}, nil
+	})
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// requestsTotal counts every Slack command request, by command and
+// response status.
+var requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "slack_command_requests_total",
+	Help: "Total Slack command requests handled, by command and status.",
+}, []string{"command", "status"})
+
+// MetricsHandler exposes all registered metrics (including the
+// internal/github package's GitHub API latency histogram) in the
+// standard Prometheus text format.
+func MetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRequest records a completed request against requestsTotal.
+func ObserveRequest(command, status string) {
+	requestsTotal.WithLabelValues(command, status).Inc()
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps an http.Handler with cross-cutting behavior.
+type Middleware func(http.Handler) http.Handler
+
+// Chain applies middlewares to h in the order given, so the first
+// middleware is outermost (runs first on the way in, last on the way
+// out).
+func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+type contextKey string
+
+const requestIDContextKey contextKey = "request_id"
+
+// RequestIDHeader is the response header the request ID is echoed
+// under, for correlating client-side logs with server-side ones.
+const RequestIDHeader = "X-Request-Id"
+
+// RequestID assigns a random ID to every request, available via
+// RequestIDFromContext and echoed back in the RequestIDHeader response
+// header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := newRequestID()
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDContextKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID set by RequestID, or ""
+// if none is present.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// statusRecorder captures the status code written through it so
+// AccessLog can report it after the handler returns.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// AccessLog logs one structured JSON line per request via log/slog,
+// including the Slack fields (command, user_id, team_id) once they're
+// available further down the chain.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		slog.Info("request",
+			"request_id", RequestIDFromContext(r.Context()),
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"latency_ms", time.Since(start).Milliseconds(),
+			"command", r.FormValue("command"),
+			"user_id", r.FormValue("user_id"),
+			"team_id", r.FormValue("team_id"),
+		)
+	})
+}
+
+// Recovery recovers from a panic in the handler chain, logs it, and
+// responds with a 500 instead of crashing the process.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.Error("panic recovered",
+					"request_id", RequestIDFromContext(r.Context()),
+					"path", r.URL.Path,
+					"panic", rec,
+				)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChain_OrdersOutermostFirst(t *testing.T) {
+	var order []string
+	record := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	}), record("first"), record("second"))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	want := []string{"first", "second", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+	for i, name := range want {
+		if order[i] != name {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestRequestID_SetsHeaderAndContext(t *testing.T) {
+	var seen string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if seen == "" {
+		t.Fatal("expected a request ID in the handler's context")
+	}
+	if rec.Header().Get(RequestIDHeader) != seen {
+		t.Errorf("expected response header %s to match context request ID", RequestIDHeader)
+	}
+}
+
+func TestRecovery_RecoversPanic(t *testing.T) {
+	h := Recovery(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 after a recovered panic, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,17 @@
+// Package server provides the HTTP plumbing shared by every Slack
+// command: a Router with a middleware chain (request ID, structured
+// access logging, panic recovery, Slack auth) and a CommandHandler
+// adapter so business logic only ever returns data, never writes to
+// http.ResponseWriter directly.
+package server
+
+// SlackResponse is the JSON payload sent back to Slack for both slash
+// commands and the Events API's chat responses.
+type SlackResponse struct {
+	ResponseType string        `json:"response_type"`
+	Blocks       []interface{} `json:"blocks,omitempty"`
+	Text         string        `json:"text,omitempty"`
+	Username     string        `json:"username,omitempty"`
+	IconEmoji    string        `json:"icon_emoji,omitempty"`
+	IconURL      string        `json:"icon_url,omitempty"`
+}
@@ -0,0 +1,36 @@
+package server
+
+import "net/http"
+
+// Router is a thin wrapper around http.ServeMux that applies a shared
+// middleware chain to every route registered on it.
+type Router struct {
+	mux         *http.ServeMux
+	middlewares []Middleware
+}
+
+// NewRouter builds a Router that applies middlewares, in order, to
+// every handler registered with Handle/HandleFunc.
+func NewRouter(middlewares ...Middleware) *Router {
+	return &Router{
+		mux:         http.NewServeMux(),
+		middlewares: middlewares,
+	}
+}
+
+// Handle registers h for pattern, wrapped in the router's middleware
+// chain.
+func (rt *Router) Handle(pattern string, h http.Handler) {
+	rt.mux.Handle(pattern, Chain(h, rt.middlewares...))
+}
+
+// HandleFunc registers h for pattern, wrapped in the router's
+// middleware chain.
+func (rt *Router) HandleFunc(pattern string, h http.HandlerFunc) {
+	rt.Handle(pattern, h)
+}
+
+// ServeHTTP makes Router an http.Handler.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt.mux.ServeHTTP(w, r)
+}